@@ -0,0 +1,104 @@
+package edgeclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	polytypes "github.com/0xPolygon/polygon-edge/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BuildLegacyTx builds an unsigned legacy transaction. Callers sign it with
+// a Signer before submitting it.
+func BuildLegacyTx(nonce uint64, to *common.Address, value *big.Int, gas uint64, gasPrice *big.Int, data []byte) *polytypes.Transaction {
+	tx := &polytypes.Transaction{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      gas,
+		Value:    value,
+		Input:    data,
+		Type:     polytypes.LegacyTx,
+	}
+	if to != nil {
+		toAddr := polytypes.BytesToAddress(to.Bytes())
+		tx.To = &toAddr
+	}
+	return tx
+}
+
+// BuildDynamicFeeTx builds an unsigned EIP-1559 transaction. Callers sign it
+// with a Signer before submitting it.
+func BuildDynamicFeeTx(chainID *big.Int, nonce uint64, to *common.Address, value *big.Int, gas uint64, gasTipCap, gasFeeCap *big.Int, data []byte) *polytypes.Transaction {
+	tx := &polytypes.Transaction{
+		Nonce:     nonce,
+		Gas:       gas,
+		Value:     value,
+		Input:     data,
+		Type:      polytypes.DynamicFeeTx,
+		ChainID:   chainID,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	}
+	if to != nil {
+		toAddr := polytypes.BytesToAddress(to.Bytes())
+		tx.To = &toAddr
+	}
+	return tx
+}
+
+// BuildTx builds an unsigned transaction, picking a legacy or EIP-1559
+// encoding based on whether the chain has London activated, as reported by
+// eth_feeHistory (a node pre-London returns a zero baseFeePerGas / errors on
+// the call).
+func (c *EdgeClient) BuildTx(ctx context.Context, nonce uint64, to *common.Address, value *big.Int, gas uint64, data []byte) (*polytypes.Transaction, error) {
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("edgeclient: BuildTx: %w", err)
+	}
+
+	londonActive, tipCap, feeCap, gasPrice, err := c.feeParams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("edgeclient: BuildTx: %w", err)
+	}
+
+	if londonActive {
+		return BuildDynamicFeeTx(chainID, nonce, to, value, gas, tipCap, feeCap, data), nil
+	}
+	return BuildLegacyTx(nonce, to, value, gas, gasPrice, data), nil
+}
+
+// feeHistoryResult mirrors the subset of eth_feeHistory's response this
+// client cares about.
+type feeHistoryResult struct {
+	BaseFeePerGas []*hexutil.Big   `json:"baseFeePerGas"`
+	Reward        [][]*hexutil.Big `json:"reward"`
+}
+
+// feeParams reports whether London is active and, if so, a reasonable
+// priority-fee/fee-cap pair derived from eth_feeHistory; otherwise it falls
+// back to eth_gasPrice for a legacy gas price.
+func (c *EdgeClient) feeParams(ctx context.Context) (londonActive bool, tipCap, feeCap, gasPrice *big.Int, err error) {
+	var history feeHistoryResult
+	callErr := c.rpc.CallContext(ctx, &history, "eth_feeHistory", "0x1", "latest", []int{50})
+	if callErr != nil || len(history.BaseFeePerGas) == 0 || history.BaseFeePerGas[len(history.BaseFeePerGas)-1].ToInt().Sign() == 0 {
+		gasPrice, err = c.GasPrice(ctx)
+		if err != nil {
+			return false, nil, nil, nil, err
+		}
+		return false, nil, nil, gasPrice, nil
+	}
+
+	baseFee := history.BaseFeePerGas[len(history.BaseFeePerGas)-1].ToInt()
+	tipCap = priorityFeeFromHistory(history.Reward)
+	feeCap = new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tipCap)
+	return true, tipCap, feeCap, nil, nil
+}
+
+func priorityFeeFromHistory(reward [][]*hexutil.Big) *big.Int {
+	if len(reward) == 0 || len(reward[0]) == 0 {
+		return big.NewInt(1_500_000_000) // 1.5 gwei default tip
+	}
+	return reward[0][0].ToInt()
+}
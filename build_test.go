@@ -0,0 +1,16 @@
+package edgeclient
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityFeeFromHistory(t *testing.T) {
+	assert.Equal(t, big.NewInt(1_500_000_000), priorityFeeFromHistory(nil), "empty history should fall back to the default tip")
+
+	reward := [][]*hexutil.Big{{(*hexutil.Big)(big.NewInt(3_000_000_000))}}
+	assert.Equal(t, big.NewInt(3_000_000_000), priorityFeeFromHistory(reward), "should use the node-reported reward when present")
+}
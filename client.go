@@ -0,0 +1,384 @@
+// Package edgeclient is a JSON-RPC client for polygon-edge ("Edge") nodes.
+// It wraps the standard eth_ namespace (as implemented by ethermint-style
+// RPCs) while preserving Edge-specific transaction fields -- most notably
+// StateTx's From field -- that get lost when responses are decoded purely
+// through go-ethereum's types.
+package edgeclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	polytypes "github.com/0xPolygon/polygon-edge/types"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/maticnetwork/polygon-cli/rpctypes"
+	"github.com/umbracle/fastrlp"
+	"golang.org/x/crypto/sha3"
+)
+
+// EdgeClient is a JSON-RPC client for an Edge node. It is safe for
+// concurrent use by multiple goroutines.
+type EdgeClient struct {
+	cfg Config
+
+	evm *ethclient.Client
+	rpc *rpc.Client
+}
+
+// NewEdgeClient dials the node described by cfg and returns a ready-to-use
+// EdgeClient. The caller owns the lifetime of the returned client and should
+// call Close when done with it.
+func NewEdgeClient(ctx context.Context, cfg Config) (*EdgeClient, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	rpcClient, err := rpc.DialContext(ctx, cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("edgeclient: dial %q: %w", cfg.URL, err)
+	}
+
+	return &EdgeClient{
+		cfg: cfg,
+		evm: ethclient.NewClient(rpcClient),
+		rpc: rpcClient,
+	}, nil
+}
+
+// Close releases the underlying RPC connection.
+func (c *EdgeClient) Close() {
+	c.rpc.Close()
+}
+
+// RPC exposes the underlying *rpc.Client for callers that need to issue a
+// method this client doesn't wrap yet.
+func (c *EdgeClient) RPC() *rpc.Client {
+	return c.rpc
+}
+
+// Eth exposes the underlying *ethclient.Client for callers -- notably the
+// verifier package -- that need to compare Edge's view of a transaction
+// against go-ethereum's.
+func (c *EdgeClient) Eth() *ethclient.Client {
+	return c.evm
+}
+
+// RawBlockByNumber calls eth_getBlockByNumber and returns the undecoded RPC
+// response, for callers that need the raw per-transaction fields rather than
+// the *polytypes.Transaction conversion BlockByNumber returns.
+func (c *EdgeClient) RawBlockByNumber(ctx context.Context, number *big.Int) (*rpctypes.RawBlockResponse, error) {
+	var raw rpctypes.RawBlockResponse
+	if err := c.rpc.CallContext(ctx, &raw, "eth_getBlockByNumber", toBlockNumArg(number), true); err != nil {
+		return nil, fmt.Errorf("edgeclient: eth_getBlockByNumber: %w", err)
+	}
+	return &raw, nil
+}
+
+// ChainID calls eth_chainId.
+func (c *EdgeClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return c.evm.ChainID(ctx)
+}
+
+// Syncing calls eth_syncing.
+func (c *EdgeClient) Syncing(ctx context.Context) (SyncStatus, error) {
+	progress, err := c.evm.SyncProgress(ctx)
+	if err != nil {
+		return SyncStatus{}, fmt.Errorf("edgeclient: eth_syncing: %w", err)
+	}
+	if progress == nil {
+		return SyncStatus{Synced: true}, nil
+	}
+	return SyncStatus{
+		StartingBlock: progress.StartingBlock,
+		CurrentBlock:  progress.CurrentBlock,
+		HighestBlock:  progress.HighestBlock,
+	}, nil
+}
+
+// GasPrice calls eth_gasPrice.
+func (c *EdgeClient) GasPrice(ctx context.Context) (*big.Int, error) {
+	return c.evm.SuggestGasPrice(ctx)
+}
+
+// BalanceAt calls eth_getBalance. A nil blockNumber means "latest".
+func (c *EdgeClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return c.evm.BalanceAt(ctx, account, blockNumber)
+}
+
+// StorageAt calls eth_getStorageAt. A nil blockNumber means "latest".
+func (c *EdgeClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return c.evm.StorageAt(ctx, account, key, blockNumber)
+}
+
+// CodeAt calls eth_getCode. A nil blockNumber means "latest".
+func (c *EdgeClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.evm.CodeAt(ctx, account, blockNumber)
+}
+
+// TransactionCount calls eth_getTransactionCount. A nil blockNumber means
+// "latest"; use PendingTransactionCount for the pending count.
+func (c *EdgeClient) TransactionCount(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return c.evm.NonceAt(ctx, account, blockNumber)
+}
+
+// PendingTransactionCount calls eth_getTransactionCount against the pending
+// block.
+func (c *EdgeClient) PendingTransactionCount(ctx context.Context, account common.Address) (uint64, error) {
+	return c.evm.PendingNonceAt(ctx, account)
+}
+
+// BlockByNumber calls eth_getBlockByNumber with full transaction objects and
+// converts the result into Edge's richer Transaction type. A nil number
+// means "latest".
+func (c *EdgeClient) BlockByNumber(ctx context.Context, number *big.Int) (*Block, error) {
+	var raw rpctypes.RawBlockResponse
+	if err := c.rpc.CallContext(ctx, &raw, "eth_getBlockByNumber", toBlockNumArg(number), true); err != nil {
+		return nil, fmt.Errorf("edgeclient: eth_getBlockByNumber: %w", err)
+	}
+	return blockFromRaw(&raw), nil
+}
+
+// BlockByHash calls eth_getBlockByHash with full transaction objects.
+func (c *EdgeClient) BlockByHash(ctx context.Context, hash common.Hash) (*Block, error) {
+	var raw rpctypes.RawBlockResponse
+	if err := c.rpc.CallContext(ctx, &raw, "eth_getBlockByHash", hash, true); err != nil {
+		return nil, fmt.Errorf("edgeclient: eth_getBlockByHash: %w", err)
+	}
+	return blockFromRaw(&raw), nil
+}
+
+// TransactionByHash calls eth_getTransactionByHash and reports whether the
+// transaction is still pending.
+func (c *EdgeClient) TransactionByHash(ctx context.Context, hash common.Hash) (tx *polytypes.Transaction, isPending bool, err error) {
+	var raw *rpctypes.RawTransactionResponse
+	if err := c.rpc.CallContext(ctx, &raw, "eth_getTransactionByHash", hash); err != nil {
+		return nil, false, fmt.Errorf("edgeclient: eth_getTransactionByHash: %w", err)
+	}
+	if raw == nil {
+		return nil, false, ethereum.NotFound
+	}
+	return rawToPolyTxn(raw), raw.BlockNumber == "", nil
+}
+
+// TransactionReceipt calls eth_getTransactionReceipt.
+func (c *EdgeClient) TransactionReceipt(ctx context.Context, hash common.Hash) (*Receipt, error) {
+	return c.evm.TransactionReceipt(ctx, hash)
+}
+
+// Call calls eth_call. A nil blockNumber means "latest".
+func (c *EdgeClient) Call(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return c.evm.CallContract(ctx, msg, blockNumber)
+}
+
+// EstimateGas calls eth_estimateGas.
+func (c *EdgeClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return c.evm.EstimateGas(ctx, msg)
+}
+
+// SendRawTransaction calls eth_sendRawTransaction with the given RLP-encoded
+// signed transaction bytes.
+func (c *EdgeClient) SendRawTransaction(ctx context.Context, rawTx []byte) (common.Hash, error) {
+	var hash common.Hash
+	if err := c.rpc.CallContext(ctx, &hash, "eth_sendRawTransaction", "0x"+common.Bytes2Hex(rawTx)); err != nil {
+		return common.Hash{}, fmt.Errorf("edgeclient: eth_sendRawTransaction: %w", err)
+	}
+	return hash, nil
+}
+
+// GetLogs calls eth_getLogs.
+func (c *EdgeClient) GetLogs(ctx context.Context, q ethereum.FilterQuery) ([]Log, error) {
+	return c.evm.FilterLogs(ctx, q)
+}
+
+// PendingTransactions calls eth_getPendingTransactions, the ethermint-style
+// extension that returns the node's mempool contents, and converts each
+// entry to Edge's Transaction type.
+func (c *EdgeClient) PendingTransactions(ctx context.Context) ([]*polytypes.Transaction, error) {
+	var raw []rpctypes.RawTransactionResponse
+	if err := c.rpc.CallContext(ctx, &raw, "eth_getPendingTransactions"); err != nil {
+		return nil, fmt.Errorf("edgeclient: eth_getPendingTransactions: %w", err)
+	}
+	txs := make([]*polytypes.Transaction, len(raw))
+	for i := range raw {
+		txs[i] = rawToPolyTxn(&raw[i])
+	}
+	return txs, nil
+}
+
+func blockFromRaw(r *rpctypes.RawBlockResponse) *Block {
+	b := &Block{
+		Number:     r.Number.ToBigInt(),
+		Hash:       r.Hash.ToHash(),
+		ParentHash: r.ParentHash.ToHash(),
+		Timestamp:  r.Timestamp.ToUint64(),
+		Miner:      r.Miner.ToAddress(),
+		GasLimit:   r.GasLimit.ToUint64(),
+		GasUsed:    r.GasUsed.ToUint64(),
+		ExtraData:  r.ExtraData.ToBytes(),
+	}
+	b.Transactions = make([]*polytypes.Transaction, len(r.Transactions))
+	for i := range r.Transactions {
+		b.Transactions[i] = rawToPolyTxn(&r.Transactions[i])
+	}
+	return b
+}
+
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	return "0x" + number.Text(16)
+}
+
+// rawToPolyTxn converts a raw JSON-RPC transaction response into Edge's
+// Transaction type, preserving the fields arenaHash needs to recompute a
+// matching hash (including StateTx's From, which it copies from the response).
+func rawToPolyTxn(r *rpctypes.RawTransactionResponse) *polytypes.Transaction {
+	toAddress := polytypes.BytesToAddress(r.To.ToAddress().Bytes())
+	p := &polytypes.Transaction{
+		Nonce:    r.Nonce.ToUint64(),
+		GasPrice: r.GasPrice.ToBigInt(),
+		Gas:      r.Gas.ToUint64(),
+		To:       &toAddress,
+		Value:    r.Value.ToBigInt(),
+		Input:    r.Input.ToBytes(),
+		V:        r.V.ToBigInt(),
+		R:        r.R.ToBigInt(),
+		S:        r.S.ToBigInt(),
+		Type:     polytypes.TxType(r.Type.ToInt64()),
+	}
+	if p.Type == polytypes.StateTx {
+		p.From = polytypes.BytesToAddress(r.From.ToAddress().Bytes())
+	}
+	if p.Type == polytypes.DynamicFeeTx {
+		p.ChainID = r.ChainID.ToBigInt()
+		p.GasTipCap = r.MaxPriorityFeePerGas.ToBigInt()
+		p.GasFeeCap = r.MaxFeePerGas.ToBigInt()
+	}
+	return p
+}
+
+// arenaHash re-implements polygon-edge's transaction hashing algorithm
+// directly against fastrlp so the client can independently verify the hash
+// a node reports for a transaction.
+//
+// For legacy and StateTx transactions, the fields that affect the hash are,
+// in order: Nonce, GasPrice, Gas, To (nilable), Value, Input, V, R, S, and --
+// only when Type == StateTx -- From.
+//
+// For DynamicFeeTx, Edge follows EIP-1559: the hash is keccak256 of the
+// 0x02 type byte followed by the RLP list [chainId, nonce,
+// maxPriorityFeePerGas, maxFeePerGas, gas, to, value, data, accessList, v,
+// r, s]. polygon-edge's Transaction has no access-list field to populate,
+// but the wire format still carries the element -- MarshalRLPWith always
+// writes an empty list there -- so it must be present and empty, not
+// omitted.
+func arenaHash(t *polytypes.Transaction) (retHash common.Hash) {
+	arena := &fastrlp.Arena{}
+	sha := sha3.NewLegacyKeccak256()
+
+	if t.Type == polytypes.DynamicFeeTx {
+		vv := arena.NewArray()
+
+		vv.Set(arena.NewBigInt(t.ChainID))
+		vv.Set(arena.NewUint(t.Nonce))
+		vv.Set(arena.NewBigInt(t.GasTipCap))
+		vv.Set(arena.NewBigInt(t.GasFeeCap))
+		vv.Set(arena.NewUint(t.Gas))
+
+		if t.To != nil {
+			vv.Set(arena.NewBytes((*t.To).Bytes()))
+		} else {
+			vv.Set(arena.NewNull())
+		}
+
+		vv.Set(arena.NewBigInt(t.Value))
+		vv.Set(arena.NewCopyBytes(t.Input))
+		vv.Set(arena.NewArray()) // empty access list -- required by the wire format
+
+		vv.Set(arena.NewBigInt(t.V))
+		vv.Set(arena.NewBigInt(t.R))
+		vv.Set(arena.NewBigInt(t.S))
+
+		sha.Write([]byte{byte(polytypes.DynamicFeeTx)})
+		sha.Write(vv.MarshalTo(nil))
+		retHash.SetBytes(sha.Sum(nil))
+		return
+	}
+
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(t.Nonce))
+	vv.Set(arena.NewBigInt(t.GasPrice))
+	vv.Set(arena.NewUint(t.Gas))
+
+	if t.To != nil {
+		vv.Set(arena.NewBytes((*t.To).Bytes()))
+	} else {
+		vv.Set(arena.NewNull())
+	}
+
+	vv.Set(arena.NewBigInt(t.Value))
+	vv.Set(arena.NewCopyBytes(t.Input))
+
+	vv.Set(arena.NewBigInt(t.V))
+	vv.Set(arena.NewBigInt(t.R))
+	vv.Set(arena.NewBigInt(t.S))
+
+	if t.Type == polytypes.StateTx {
+		vv.Set(arena.NewBytes((t.From).Bytes()))
+	}
+
+	buf := vv.MarshalTo(nil)
+	sha.Write(buf)
+
+	retHash.SetBytes(sha.Sum(nil))
+	return
+}
+
+// ArenaHash exposes arenaHash to other packages -- notably edgeclient/
+// verifier -- that need to independently recompute a transaction's hash
+// rather than trust ComputeHash().
+func ArenaHash(t *polytypes.Transaction) common.Hash {
+	return arenaHash(t)
+}
+
+// RawTxToTransaction exposes rawToPolyTxn to other packages that have their
+// own raw RPC transaction response to convert.
+func RawTxToTransaction(r *rpctypes.RawTransactionResponse) *polytypes.Transaction {
+	return rawToPolyTxn(r)
+}
+
+// GethTxToTransaction converts a go-ethereum-sourced transaction into Edge's
+// Transaction type, for comparing the hash Edge computes against the hash
+// go-ethereum computes. Unlike RawTxToTransaction, V/R/S cannot be read back
+// out of ethtypes.Transaction uniformly across tx types, so they -- and
+// StateTx's From -- are left zero; callers comparing hashes should expect
+// ArenaHash on the result to disagree with the RPC-sourced hash whenever the
+// original transaction carried a signature or was a StateTx.
+func GethTxToTransaction(gt *ethtypes.Transaction) *polytypes.Transaction {
+	toAddress := polytypes.BytesToAddress(gt.To().Bytes())
+	p := &polytypes.Transaction{
+		Nonce:    gt.Nonce(),
+		GasPrice: gt.GasPrice(),
+		Gas:      gt.Gas(),
+		To:       &toAddress,
+		Value:    gt.Value(),
+		Input:    gt.Data(),
+		V:        big.NewInt(0),
+		R:        big.NewInt(0),
+		S:        big.NewInt(0),
+		Type:     polytypes.TxType(gt.Type()),
+	}
+	if p.Type == polytypes.DynamicFeeTx {
+		p.ChainID = gt.ChainId()
+		p.GasTipCap = gt.GasTipCap()
+		p.GasFeeCap = gt.GasFeeCap()
+	}
+	return p
+}
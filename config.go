@@ -0,0 +1,26 @@
+package edgeclient
+
+import "fmt"
+
+// Config holds the parameters needed to dial an Edge (polygon-edge) node and,
+// optionally, sign and submit transactions against it. Callers that only need
+// read access can leave PrivateKey and ToAddress empty.
+type Config struct {
+	// URL is the HTTP(S) or WS(S) JSON-RPC endpoint of the Edge node.
+	URL string
+
+	// PrivateKey is a hex-encoded ECDSA private key (with or without the
+	// leading "0x") used by Signer to sign outgoing transactions.
+	PrivateKey string
+
+	// ToAddress is the default recipient used by callers that build test or
+	// smoke-test transactions against the node.
+	ToAddress string
+}
+
+func (c Config) validate() error {
+	if c.URL == "" {
+		return fmt.Errorf("edgeclient: Config.URL must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package edgeclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	polytypes "github.com/0xPolygon/polygon-edge/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// minPriceBumpPercent is the minimum percentage increase the node requires
+// on replacement gas price, mirroring go-ethereum/polygon-edge's txpool
+// price-bump rule for replacing a pending transaction by nonce.
+const minPriceBumpPercent = 10
+
+// Resend looks up originalHash in the node's pending pool, rebuilds an
+// identical transaction (same nonce/to/value/input) with bumped gas
+// parameters, re-signs it with signer, and resubmits it -- the Edge
+// equivalent of ethermint's eth_resend. It is the way to unstick a
+// transaction that's been sitting in the mempool too long.
+func (c *EdgeClient) Resend(ctx context.Context, signer *Signer, originalHash common.Hash, newGasPrice *big.Int, newGasLimit uint64, chainID *big.Int) (common.Hash, error) {
+	original, isPending, err := c.TransactionByHash(ctx, originalHash)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("edgeclient: resend: look up original tx: %w", err)
+	}
+	if !isPending {
+		return common.Hash{}, fmt.Errorf("edgeclient: resend: tx %s is already mined, refusing to resend", originalHash)
+	}
+	if original.Type == polytypes.DynamicFeeTx {
+		return common.Hash{}, fmt.Errorf("edgeclient: resend: tx %s is an EIP-1559 transaction; Resend only supports legacy gas-price bumps, not GasTipCap/GasFeeCap", originalHash)
+	}
+
+	if err := checkNotConsumed(ctx, c, signer, original); err != nil {
+		return common.Hash{}, err
+	}
+
+	if err := checkPriceBump(original.GasPrice, newGasPrice); err != nil {
+		return common.Hash{}, err
+	}
+
+	replacement := &polytypes.Transaction{
+		Nonce:    original.Nonce,
+		GasPrice: newGasPrice,
+		Gas:      newGasLimit,
+		To:       original.To,
+		Value:    original.Value,
+		Input:    original.Input,
+		Type:     original.Type,
+		From:     original.From,
+	}
+
+	return signer.SignAndSend(ctx, c, replacement, chainID)
+}
+
+// checkNotConsumed returns an error if the chain's current nonce for the
+// signer's address is already past the original transaction's nonce, which
+// means it was mined (or replaced) out from under us between the
+// TransactionByHash call and now.
+func checkNotConsumed(ctx context.Context, c *EdgeClient, signer *Signer, original *polytypes.Transaction) error {
+	currentNonce, err := c.TransactionCount(ctx, signer.Address(), nil)
+	if err != nil {
+		return fmt.Errorf("edgeclient: resend: check current nonce: %w", err)
+	}
+	if currentNonce > original.Nonce {
+		return fmt.Errorf("edgeclient: resend: nonce %d already consumed on-chain", original.Nonce)
+	}
+	return nil
+}
+
+// checkPriceBump enforces the node's minimum gas-price-bump rule for
+// replacing a pending transaction.
+func checkPriceBump(oldGasPrice, newGasPrice *big.Int) error {
+	minBump := new(big.Int).Div(new(big.Int).Mul(oldGasPrice, big.NewInt(100+minPriceBumpPercent)), big.NewInt(100))
+	if newGasPrice.Cmp(minBump) < 0 {
+		return fmt.Errorf("edgeclient: resend: new gas price %s is below the required %d%% bump over %s (minimum %s)", newGasPrice, minPriceBumpPercent, oldGasPrice, minBump)
+	}
+	return nil
+}
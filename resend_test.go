@@ -0,0 +1,16 @@
+package edgeclient
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPriceBump(t *testing.T) {
+	oldPrice := big.NewInt(100)
+
+	assert.Error(t, checkPriceBump(oldPrice, big.NewInt(105)), "5% bump should be rejected")
+	assert.NoError(t, checkPriceBump(oldPrice, big.NewInt(110)), "10% bump should be accepted")
+	assert.NoError(t, checkPriceBump(oldPrice, big.NewInt(200)), "100% bump should be accepted")
+}
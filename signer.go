@@ -0,0 +1,280 @@
+package edgeclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	polytypes "github.com/0xPolygon/polygon-edge/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/umbracle/fastrlp"
+	"golang.org/x/crypto/sha3"
+)
+
+// Signer signs polytypes.Transaction values the way polygon-edge itself
+// signs and recovers transactions: EIP-155 V/R/S encoding for LegacyTx, and
+// plain EIP-2718 y-parity V encoding -- chainID-prefixed, type-byte-prefixed
+// hash -- for every other tx type, StateTx included. Building a transaction
+// with go-ethereum's Transaction type and then converting it
+// (GethTxToTransaction) loses V/R/S because go-ethereum doesn't expose them
+// uniformly across tx types, so Signer signs the polytypes.Transaction
+// directly instead.
+type Signer struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewSigner parses a hex-encoded ECDSA private key (with or without a "0x"
+// prefix) and returns a Signer for it.
+func NewSigner(hexKey string) (*Signer, error) {
+	key, err := crypto.HexToECDSA(trim0x(hexKey))
+	if err != nil {
+		return nil, fmt.Errorf("edgeclient: parse private key: %w", err)
+	}
+	return &Signer{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}, nil
+}
+
+// Address returns the address corresponding to the signer's key.
+func (s *Signer) Address() common.Address {
+	return s.address
+}
+
+// Sign populates tx's V/R/S fields in place and returns the same
+// transaction for convenience. LegacyTx uses EIP-155 V encoding
+// (recoveryID + {chainID*2 + 35}); every other type -- StateTx included --
+// uses plain EIP-2718 y-parity V (the recoveryID itself, 0 or 1).
+func (s *Signer) Sign(tx *polytypes.Transaction, chainID *big.Int) (*polytypes.Transaction, error) {
+	hash := signingHash(tx, chainID)
+
+	sig, err := crypto.Sign(hash.Bytes(), s.key)
+	if err != nil {
+		return nil, fmt.Errorf("edgeclient: sign transaction: %w", err)
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	v := new(big.Int).SetBytes(sig[64:])
+	ss := new(big.Int).SetBytes(sig[32:64])
+
+	if tx.Type == polytypes.LegacyTx {
+		// EIP-155: v = recoveryID + {chainID*2 + 35}
+		v.Add(v, new(big.Int).Add(new(big.Int).Mul(chainID, big.NewInt(2)), big.NewInt(35)))
+	}
+
+	tx.V = v
+	tx.R = r
+	tx.S = ss
+	return tx, nil
+}
+
+// Sender recovers the address that signed tx by reversing its V encoding
+// (EIP-155 for LegacyTx, plain y-parity for every other type) and running
+// keccak256-based ECDSA recovery over the payload signingHash builds, the
+// same one Sign signs.
+func Sender(tx *polytypes.Transaction, chainID *big.Int) (common.Address, error) {
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return common.Address{}, fmt.Errorf("edgeclient: transaction is unsigned")
+	}
+
+	recoveryID := tx.V
+	if tx.Type == polytypes.LegacyTx {
+		recoveryID = new(big.Int).Sub(tx.V, new(big.Int).Add(new(big.Int).Mul(chainID, big.NewInt(2)), big.NewInt(35)))
+	}
+	if recoveryID.Sign() < 0 || recoveryID.Cmp(big.NewInt(1)) > 0 {
+		return common.Address{}, fmt.Errorf("edgeclient: invalid V value %s for chain ID %s", tx.V, chainID)
+	}
+
+	hash := signingHash(tx, chainID)
+
+	sig := make([]byte, 65)
+	copy(sig[:32], padTo32(tx.R.Bytes()))
+	copy(sig[32:64], padTo32(tx.S.Bytes()))
+	sig[64] = byte(recoveryID.Uint64())
+
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("edgeclient: recover sender: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// SignAndSend signs tx for chainID and submits it via eth_sendRawTransaction
+// using Edge's native RLP marshalling, returning the resulting transaction
+// hash.
+func (s *Signer) SignAndSend(ctx context.Context, c *EdgeClient, tx *polytypes.Transaction, chainID *big.Int) (common.Hash, error) {
+	if _, err := s.Sign(tx, chainID); err != nil {
+		return common.Hash{}, err
+	}
+	return c.SendRawTransaction(ctx, marshalRLP(tx))
+}
+
+// signingHash is the RLP payload Sign/Sender hash over.
+//
+// For LegacyTx, it's the same fields arenaHash hashes minus V/R/S, plus the
+// EIP-155 chainID/0/0 triple appended in their place -- the standard
+// EIP-155 "signing RLP".
+//
+// For every other type -- DynamicFeeTx and StateTx -- it follows
+// polygon-edge's calcTxHash: a keccak256 of the type byte followed by the RLP
+// list [chainId, nonce, <gas price field(s)>, gas, to, value, data,
+// accessList], with an always-empty accessList in the position EIP-1559
+// reserves for it (polygon-edge's Transaction has no access-list field to
+// populate, but the wire format still carries the element). There's no
+// EIP-155 chainID-replaces-signature trick here -- chainID is already a
+// real field of the payload.
+func signingHash(t *polytypes.Transaction, chainID *big.Int) (h common.Hash) {
+	arena := &fastrlp.Arena{}
+	sha := sha3.NewLegacyKeccak256()
+
+	switch t.Type {
+	case polytypes.DynamicFeeTx:
+		vv := arena.NewArray()
+
+		vv.Set(arena.NewBigInt(chainID))
+		vv.Set(arena.NewUint(t.Nonce))
+		vv.Set(arena.NewBigInt(t.GasTipCap))
+		vv.Set(arena.NewBigInt(t.GasFeeCap))
+		vv.Set(arena.NewUint(t.Gas))
+
+		if t.To != nil {
+			vv.Set(arena.NewBytes((*t.To).Bytes()))
+		} else {
+			vv.Set(arena.NewNull())
+		}
+
+		vv.Set(arena.NewBigInt(t.Value))
+		vv.Set(arena.NewCopyBytes(t.Input))
+		vv.Set(arena.NewArray()) // empty access list -- required by the wire format
+
+		sha.Write([]byte{byte(polytypes.DynamicFeeTx)})
+		sha.Write(vv.MarshalTo(nil))
+		h.SetBytes(sha.Sum(nil))
+		return
+
+	case polytypes.StateTx:
+		vv := arena.NewArray()
+
+		vv.Set(arena.NewBigInt(chainID))
+		vv.Set(arena.NewUint(t.Nonce))
+		vv.Set(arena.NewBigInt(t.GasPrice))
+		vv.Set(arena.NewUint(t.Gas))
+
+		if t.To != nil {
+			vv.Set(arena.NewBytes((*t.To).Bytes()))
+		} else {
+			vv.Set(arena.NewNull())
+		}
+
+		vv.Set(arena.NewBigInt(t.Value))
+		vv.Set(arena.NewCopyBytes(t.Input))
+		vv.Set(arena.NewArray()) // empty access list -- required by the wire format
+
+		sha.Write([]byte{byte(polytypes.StateTx)})
+		sha.Write(vv.MarshalTo(nil))
+		h.SetBytes(sha.Sum(nil))
+		return
+	}
+
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(t.Nonce))
+	vv.Set(arena.NewBigInt(t.GasPrice))
+	vv.Set(arena.NewUint(t.Gas))
+
+	if t.To != nil {
+		vv.Set(arena.NewBytes((*t.To).Bytes()))
+	} else {
+		vv.Set(arena.NewNull())
+	}
+
+	vv.Set(arena.NewBigInt(t.Value))
+	vv.Set(arena.NewCopyBytes(t.Input))
+
+	vv.Set(arena.NewBigInt(chainID))
+	vv.Set(arena.NewUint(0))
+	vv.Set(arena.NewUint(0))
+
+	sha.Write(vv.MarshalTo(nil))
+	h.SetBytes(sha.Sum(nil))
+	return
+}
+
+// marshalRLP RLP-encodes a signed transaction using Edge-native field
+// ordering (the same fields arenaHash commits to, in order), rather than
+// go-ethereum's ethtypes.Transaction.MarshalBinary, which doesn't know about
+// StateTx's From field. DynamicFeeTx is prefixed with the 0x02 EIP-2718 type
+// byte, matching arenaHash and eth_sendRawTransaction's expected encoding.
+func marshalRLP(t *polytypes.Transaction) []byte {
+	arena := &fastrlp.Arena{}
+
+	if t.Type == polytypes.DynamicFeeTx {
+		vv := arena.NewArray()
+
+		vv.Set(arena.NewBigInt(t.ChainID))
+		vv.Set(arena.NewUint(t.Nonce))
+		vv.Set(arena.NewBigInt(t.GasTipCap))
+		vv.Set(arena.NewBigInt(t.GasFeeCap))
+		vv.Set(arena.NewUint(t.Gas))
+
+		if t.To != nil {
+			vv.Set(arena.NewBytes((*t.To).Bytes()))
+		} else {
+			vv.Set(arena.NewNull())
+		}
+
+		vv.Set(arena.NewBigInt(t.Value))
+		vv.Set(arena.NewCopyBytes(t.Input))
+		vv.Set(arena.NewArray()) // empty access list -- required by the wire format
+
+		vv.Set(arena.NewBigInt(t.V))
+		vv.Set(arena.NewBigInt(t.R))
+		vv.Set(arena.NewBigInt(t.S))
+
+		return append([]byte{byte(polytypes.DynamicFeeTx)}, vv.MarshalTo(nil)...)
+	}
+
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(t.Nonce))
+	vv.Set(arena.NewBigInt(t.GasPrice))
+	vv.Set(arena.NewUint(t.Gas))
+
+	if t.To != nil {
+		vv.Set(arena.NewBytes((*t.To).Bytes()))
+	} else {
+		vv.Set(arena.NewNull())
+	}
+
+	vv.Set(arena.NewBigInt(t.Value))
+	vv.Set(arena.NewCopyBytes(t.Input))
+
+	vv.Set(arena.NewBigInt(t.V))
+	vv.Set(arena.NewBigInt(t.R))
+	vv.Set(arena.NewBigInt(t.S))
+
+	if t.Type == polytypes.StateTx {
+		vv.Set(arena.NewBytes((t.From).Bytes()))
+	}
+
+	return vv.MarshalTo(nil)
+}
+
+func padTo32(b []byte) []byte {
+	if len(b) >= 32 {
+		return b
+	}
+	padded := make([]byte, 32)
+	copy(padded[32-len(b):], b)
+	return padded
+}
+
+func trim0x(hexKey string) string {
+	if len(hexKey) >= 2 && hexKey[0] == '0' && (hexKey[1] == 'x' || hexKey[1] == 'X') {
+		return hexKey[2:]
+	}
+	return hexKey
+}
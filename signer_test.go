@@ -0,0 +1,48 @@
+package edgeclient
+
+import (
+	"math/big"
+	"testing"
+
+	polytypes "github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignerSignAndRecover signs a transaction of each TxType and verifies
+// that Sender recovers the signer's own address -- no live node required.
+func TestSignerSignAndRecover(t *testing.T) {
+	const testPrivateKey = "0x4646464646464646464646464646464646464646464646464646464646464646"
+
+	signer, err := NewSigner(testPrivateKey)
+	require.NoError(t, err)
+
+	chainID := big.NewInt(100)
+	to := polytypes.BytesToAddress([]byte{0x01})
+
+	for _, txType := range []polytypes.TxType{polytypes.LegacyTx, polytypes.StateTx, polytypes.DynamicFeeTx} {
+		tx := &polytypes.Transaction{
+			Nonce:    0,
+			GasPrice: big.NewInt(1),
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(1),
+			Type:     txType,
+		}
+		if txType == polytypes.StateTx {
+			tx.From = polytypes.BytesToAddress(signer.Address().Bytes())
+		}
+		if txType == polytypes.DynamicFeeTx {
+			tx.ChainID = chainID
+			tx.GasTipCap = big.NewInt(1)
+			tx.GasFeeCap = big.NewInt(2)
+		}
+
+		_, err := signer.Sign(tx, chainID)
+		require.NoError(t, err)
+
+		sender, err := Sender(tx, chainID)
+		require.NoError(t, err)
+		assert.Equal(t, signer.Address(), sender, "recovered sender for TxType %d", txType)
+	}
+}
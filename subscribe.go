@@ -0,0 +1,54 @@
+package edgeclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RPCResult is the raw eth_subscribe("newHeads") notification payload --
+// just enough of the block header for callers deciding whether to re-check
+// a pending transaction, without pulling in the full Block conversion.
+type RPCResult struct {
+	Number     string `json:"number"`
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parentHash"`
+}
+
+// SubscribeNewHeads subscribes to eth_subscribe("newHeads") over the
+// client's RPC connection and delivers each new block header to ch. ctx
+// bounds only the eth_subscribe call itself; the subscription stays live
+// until the caller calls Unsubscribe on the returned Subscription or the
+// connection fails. Callers should check
+// IsNotificationsUnsupported on a non-nil error: HTTP-only Edge
+// deployments, the common case, don't support eth_subscribe at all and
+// callers should fall back to polling instead of treating it as fatal.
+func (c *EdgeClient) SubscribeNewHeads(ctx context.Context, ch chan<- *RPCResult) (ethereum.Subscription, error) {
+	sub, err := c.rpc.EthSubscribe(ctx, ch, "newHeads")
+	if err != nil {
+		return nil, fmt.Errorf("edgeclient: eth_subscribe(newHeads): %w", err)
+	}
+	return sub, nil
+}
+
+// SubscribeLogs subscribes to eth_subscribe("logs", q) and delivers each
+// matching log to ch. See SubscribeNewHeads for the HTTP-only caveat.
+func (c *EdgeClient) SubscribeLogs(ctx context.Context, q FilterQuery, ch chan<- Log) (ethereum.Subscription, error) {
+	sub, err := c.evm.SubscribeFilterLogs(ctx, q, ch)
+	if err != nil {
+		return nil, fmt.Errorf("edgeclient: eth_subscribe(logs): %w", err)
+	}
+	return sub, nil
+}
+
+// IsNotificationsUnsupported reports whether err is the error a node
+// returns when asked to eth_subscribe over a transport that can't deliver
+// notifications -- plain HTTP, as opposed to a websocket or IPC endpoint.
+// This is the common case for Edge deployments, and callers should treat it
+// as "fall back to polling" rather than a hard failure.
+func IsNotificationsUnsupported(err error) bool {
+	return errors.Is(err, rpc.ErrNotificationsUnsupported)
+}
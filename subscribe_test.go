@@ -0,0 +1,16 @@
+package edgeclient
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNotificationsUnsupported(t *testing.T) {
+	assert.True(t, IsNotificationsUnsupported(rpc.ErrNotificationsUnsupported))
+	assert.True(t, IsNotificationsUnsupported(fmt.Errorf("edgeclient: eth_subscribe(newHeads): %w", rpc.ErrNotificationsUnsupported)), "should match through %w wrapping")
+	assert.False(t, IsNotificationsUnsupported(errors.New("connection refused")))
+}
@@ -0,0 +1,65 @@
+package edgeclient
+
+import (
+	"math/big"
+
+	polytypes "github.com/0xPolygon/polygon-edge/types"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Block is the Edge-native representation of a block returned by
+// eth_getBlockByNumber/eth_getBlockByHash. Transactions are converted to
+// *polytypes.Transaction (rather than left as geth's ethtypes.Transaction) so
+// that Edge-specific fields such as StateTx's From and epoch-commit metadata
+// survive the round trip.
+type Block struct {
+	Number       *big.Int
+	Hash         common.Hash
+	ParentHash   common.Hash
+	Timestamp    uint64
+	Miner        common.Address
+	GasLimit     uint64
+	GasUsed      uint64
+	Transactions []*polytypes.Transaction
+
+	// ExtraData carries the raw IBFT extra-data field. On epoch blocks this
+	// encodes the validator set change for the epoch; callers that care
+	// about epoch metadata should decode it with the IBFT/polygon-edge
+	// consensus package rather than here, since decoding is consensus-engine
+	// specific.
+	ExtraData []byte
+}
+
+// IsEpochBlock reports whether the block's number falls on an epoch boundary
+// for the given epoch size, i.e. it is the block that carries a validator
+// set update.
+func (b *Block) IsEpochBlock(epochSize uint64) bool {
+	if epochSize == 0 || b.Number == nil {
+		return false
+	}
+	return b.Number.Uint64()%epochSize == 0
+}
+
+// SyncStatus mirrors the result of eth_syncing. Synced is true when the node
+// reports `false` (i.e. it is not syncing), in which case the other fields
+// are zero.
+type SyncStatus struct {
+	Synced        bool
+	StartingBlock uint64
+	CurrentBlock  uint64
+	HighestBlock  uint64
+}
+
+// Receipt is a thin alias over go-ethereum's receipt type; Edge does not
+// currently add fields beyond the standard Ethereum receipt.
+type Receipt = ethtypes.Receipt
+
+// Log is a thin alias over go-ethereum's log type.
+type Log = ethtypes.Log
+
+// FilterQuery is a thin alias over go-ethereum's filter query, reused so
+// callers building eth_getLogs/eth_subscribe filters don't need to import
+// go-ethereum directly.
+type FilterQuery = ethereum.FilterQuery
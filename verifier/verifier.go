@@ -0,0 +1,198 @@
+// Package verifier walks a range of blocks on an Edge node and cross-checks
+// transaction and block hashes computed five different ways: through
+// go-ethereum's ethclient, through the raw JSON-RPC response, by
+// recomputing arenaHash from the RPC fields, by recomputing arenaHash from
+// the geth fields, and through polytypes.Transaction.ComputeHash(). It
+// exists to catch polygon-edge hash-mismatch regressions (missing V/R/S,
+// StateTx From, EIP-1559 field drift, ...) in CI rather than by scraping
+// `go test -v` output, which is how the ad-hoc tests it replaces used to
+// work.
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	polytypes "github.com/0xPolygon/polygon-edge/types"
+	edgeclient "github.com/maticnetwork/polygon-cli/edgeclient"
+	"github.com/maticnetwork/polygon-cli/rpctypes"
+)
+
+// Verifier checks block and transaction hash consistency against a live
+// Edge node.
+type Verifier struct {
+	client *edgeclient.EdgeClient
+}
+
+// New returns a Verifier backed by client.
+func New(client *edgeclient.EdgeClient) *Verifier {
+	return &Verifier{client: client}
+}
+
+// Diff categorizes a single hash-mismatch finding for a transaction.
+type Diff string
+
+const (
+	// DiffMissingSignature means the geth-sourced recomputation disagrees
+	// with the RPC-sourced one because V/R/S weren't carried over.
+	DiffMissingSignature Diff = "missing-vrs"
+	// DiffStateTxFromMismatch means a StateTx's From field didn't round-trip
+	// into the geth-sourced conversion.
+	DiffStateTxFromMismatch Diff = "state-tx-from-mismatch"
+	// DiffEIP1559FieldDrift means GasTipCap/GasFeeCap were dropped or wrong.
+	DiffEIP1559FieldDrift Diff = "eip1559-field-drift"
+	// DiffTxCountMismatch means the raw RPC block and the geth-decoded block
+	// disagreed on the number of transactions, so this transaction has no
+	// geth-sourced counterpart to cross-check against.
+	DiffTxCountMismatch Diff = "tx-count-mismatch"
+	// DiffHashMismatch is a catch-all for any other hash discrepancy.
+	DiffHashMismatch Diff = "hash-mismatch"
+)
+
+// TxReport records, for a single transaction, every way the Verifier
+// computed its hash and where they disagreed.
+type TxReport struct {
+	EthclientHash     string `json:"ethclientHash"`
+	RawRPCHash        string `json:"rawRpcHash"`
+	ArenaHashFromRPC  string `json:"arenaHashFromRpc"`
+	ArenaHashFromGeth string `json:"arenaHashFromGeth"`
+	ComputeHash       string `json:"computeHash"`
+	Diffs             []Diff `json:"diffs,omitempty"`
+}
+
+// OK reports whether every hash source agreed.
+func (r TxReport) OK() bool {
+	return len(r.Diffs) == 0
+}
+
+// BlockReport records the per-block, per-transaction verification results.
+type BlockReport struct {
+	Number          uint64     `json:"number"`
+	Hash            string     `json:"hash"`
+	ParentHash      string     `json:"parentHash"`
+	ParentLinkageOK bool       `json:"parentLinkageOk"`
+	Transactions    []TxReport `json:"transactions"`
+}
+
+// OK reports whether the block and all its transactions verified cleanly.
+func (r BlockReport) OK() bool {
+	if !r.ParentLinkageOK {
+		return false
+	}
+	for _, tx := range r.Transactions {
+		if !tx.OK() {
+			return false
+		}
+	}
+	return true
+}
+
+// Report is the result of verifying a range of blocks.
+type Report struct {
+	From   uint64        `json:"from"`
+	To     uint64        `json:"to"`
+	Blocks []BlockReport `json:"blocks"`
+}
+
+// OK reports whether every block in the range verified cleanly.
+func (r Report) OK() bool {
+	for _, b := range r.Blocks {
+		if !b.OK() {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyRange verifies every block in [from, to], inclusive.
+func (v *Verifier) VerifyRange(ctx context.Context, from, to uint64) (Report, error) {
+	report := Report{From: from, To: to}
+
+	var expectedParentHash string
+	for n := from; n <= to; n++ {
+		blockReport, err := v.verifyBlock(ctx, n, expectedParentHash)
+		if err != nil {
+			return Report{}, fmt.Errorf("verifier: block %d: %w", n, err)
+		}
+		report.Blocks = append(report.Blocks, blockReport)
+		expectedParentHash = blockReport.Hash
+	}
+	return report, nil
+}
+
+// VerifyBlock verifies a single block, without checking parent linkage
+// against a previously-fetched block.
+func (v *Verifier) VerifyBlock(ctx context.Context, n *big.Int) (BlockReport, error) {
+	return v.verifyBlock(ctx, n.Uint64(), "")
+}
+
+func (v *Verifier) verifyBlock(ctx context.Context, n uint64, expectedParentHash string) (BlockReport, error) {
+	number := new(big.Int).SetUint64(n)
+
+	rawBlock, err := v.client.RawBlockByNumber(ctx, number)
+	if err != nil {
+		return BlockReport{}, fmt.Errorf("raw block: %w", err)
+	}
+	gethBlock, err := v.client.Eth().BlockByNumber(ctx, number)
+	if err != nil {
+		return BlockReport{}, fmt.Errorf("geth block: %w", err)
+	}
+
+	report := BlockReport{
+		Number:          n,
+		Hash:            rawBlock.Hash.ToHash().String(),
+		ParentHash:      rawBlock.ParentHash.ToHash().String(),
+		ParentLinkageOK: expectedParentHash == "" || expectedParentHash == rawBlock.ParentHash.ToHash().String(),
+	}
+
+	for i := range rawBlock.Transactions {
+		rawTx := &rawBlock.Transactions[i]
+
+		var gethTx *polytypes.Transaction
+		var ethclientHash string
+		if i < gethBlock.Transactions().Len() {
+			gethTx = edgeclient.GethTxToTransaction(gethBlock.Transactions()[i])
+			ethclientHash = gethBlock.Transactions()[i].Hash().String()
+		}
+
+		txReport := verifyTx(rawTx, ethclientHash, gethTx, n)
+		if gethTx == nil {
+			txReport.Diffs = append(txReport.Diffs, DiffTxCountMismatch)
+		}
+		report.Transactions = append(report.Transactions, txReport)
+	}
+	return report, nil
+}
+
+func verifyTx(rawTx *rpctypes.RawTransactionResponse, ethclientHash string, gethTx *polytypes.Transaction, blockNumber uint64) TxReport {
+	polyTx := edgeclient.RawTxToTransaction(rawTx)
+
+	report := TxReport{
+		EthclientHash:    ethclientHash,
+		RawRPCHash:       rawTx.Hash.ToHash().String(),
+		ArenaHashFromRPC: edgeclient.ArenaHash(polyTx).String(),
+		ComputeHash:      polyTx.ComputeHash(blockNumber).Hash.String(),
+	}
+	if gethTx != nil {
+		report.ArenaHashFromGeth = edgeclient.ArenaHash(gethTx).String()
+	}
+
+	if report.RawRPCHash != report.ComputeHash || report.RawRPCHash != report.ArenaHashFromRPC ||
+		(report.EthclientHash != "" && report.EthclientHash != report.RawRPCHash) {
+		report.Diffs = append(report.Diffs, DiffHashMismatch)
+	}
+	if report.ArenaHashFromGeth != "" && report.ArenaHashFromGeth != report.ArenaHashFromRPC {
+		if polyTx.Type == polytypes.StateTx && polyTx.From != gethTx.From {
+			report.Diffs = append(report.Diffs, DiffStateTxFromMismatch)
+		} else if polyTx.Type == polytypes.DynamicFeeTx && gethTx.Type == polytypes.DynamicFeeTx &&
+			(polyTx.GasTipCap.Cmp(gethTx.GasTipCap) != 0 || polyTx.GasFeeCap.Cmp(gethTx.GasFeeCap) != 0) {
+			report.Diffs = append(report.Diffs, DiffEIP1559FieldDrift)
+		} else if polyTx.V != nil && polyTx.V.Sign() != 0 {
+			report.Diffs = append(report.Diffs, DiffMissingSignature)
+		} else {
+			report.Diffs = append(report.Diffs, DiffHashMismatch)
+		}
+	}
+	return report
+}